@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// goExpr renders or as a Go boolean expression over a *plural.Operands
+// variable named "ops", matching the style of the hand-written
+// PluralFuncs it replaces.
+func (or orCondition) goExpr() string {
+	parts := make([]string, len(or))
+	for i, and := range or {
+		parts[i] = and.goExpr()
+	}
+	return strings.Join(parts, " || ")
+}
+
+func (and andCondition) goExpr() string {
+	parts := make([]string, len(and))
+	for i, rel := range and {
+		parts[i] = rel.goExpr()
+	}
+	expr := strings.Join(parts, " && ")
+	if len(and) > 1 {
+		return "(" + expr + ")"
+	}
+	return expr
+}
+
+func (r *relation) goExpr() string {
+	lhs := "ops." + strings.ToUpper(string(r.op))
+	if r.mod != 0 {
+		lhs = fmt.Sprintf("%s %% %d", lhs, r.mod)
+	}
+	eqParts := make([]string, len(r.ranges))
+	for i, rng := range r.ranges {
+		if rng.low == rng.high {
+			eqParts[i] = fmt.Sprintf("%s == %d", lhs, rng.low)
+		} else {
+			eqParts[i] = fmt.Sprintf("(%s >= %d && %s <= %d)", lhs, rng.low, lhs, rng.high)
+		}
+	}
+	expr := strings.Join(eqParts, " || ")
+	if len(eqParts) > 1 {
+		expr = "(" + expr + ")"
+	}
+	if r.negate {
+		if len(r.ranges) == 1 && r.ranges[0].low == r.ranges[0].high {
+			return fmt.Sprintf("%s != %d", lhs, r.ranges[0].low)
+		}
+		return "!" + expr
+	}
+	return expr
+}
+
+// categoryIdent returns the plural.Category identifier for a CLDR
+// category name, e.g. "few" -> "Few".
+func categoryIdent(name string) string {
+	if name == "" {
+		return ""
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// sample is a single CLDR @integer/@decimal sample value, used to
+// generate a round-trip test for each locale's plural function.
+type sample struct {
+	value   string
+	isFloat bool
+}
+
+// parseSamples parses a CLDR sample list like "1, 3~10, 100" into
+// representative values, ignoring the "…" ellipsis CLDR uses to mark a
+// truncated list and collapsing "a~b" ranges down to their first value.
+func parseSamples(s string) []sample {
+	var samples []sample
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		part = strings.TrimSpace(strings.TrimSuffix(part, "…"))
+		if part == "" {
+			continue
+		}
+		if i := strings.Index(part, "~"); i >= 0 {
+			part = strings.TrimSpace(part[:i])
+		}
+		if part == "" {
+			continue
+		}
+		samples = append(samples, sample{value: part, isFloat: strings.Contains(part, ".")})
+	}
+	return samples
+}
@@ -0,0 +1,236 @@
+// Command codegen regenerates i18n/language/pluralspec_gen.go from the
+// Unicode CLDR's plurals.xml. This file implements the CLDR plural rule
+// grammar: https://unicode.org/reports/tr35/tr35-numbers.html#Plural_rules_syntax.
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// operand is one of CLDR's plural operands (n, i, v, w, f, t).
+type operand byte
+
+const (
+	operandN operand = 'n'
+	operandI operand = 'i'
+	operandV operand = 'v'
+	operandW operand = 'w'
+	operandF operand = 'f'
+	operandT operand = 't'
+)
+
+// valueRange is an inclusive range of integers, or a single value when
+// low == high.
+type valueRange struct {
+	low, high int
+}
+
+// relation compares a (possibly mod-reduced) operand against a list of
+// ranges, e.g. "i % 100 = 3..10".
+type relation struct {
+	op     operand
+	mod    int // 0 means "no modulus"
+	negate bool
+	ranges []valueRange
+}
+
+// andCondition is a conjunction of relations.
+type andCondition []*relation
+
+// orCondition is a disjunction of andConditions; it is the parsed form of
+// a single <pluralRule> element's condition.
+type orCondition []andCondition
+
+// parseCondition parses a CLDR plural rule condition such as
+// "v = 0 and i % 10 = 1" or "i = 1 or f = 1". An empty expr (the implicit
+// "other" rule) parses to a nil orCondition.
+func parseCondition(expr string) (orCondition, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, nil
+	}
+	var or orCondition
+	for _, andExpr := range strings.Split(expr, " or ") {
+		var and andCondition
+		for _, relExpr := range strings.Split(andExpr, " and ") {
+			rel, err := parseRelation(strings.TrimSpace(relExpr))
+			if err != nil {
+				return nil, err
+			}
+			and = append(and, rel)
+		}
+		or = append(or, and)
+	}
+	return or, nil
+}
+
+func parseRelation(s string) (*relation, error) {
+	negate := strings.Contains(s, "!=")
+	sep := "="
+	if negate {
+		sep = "!="
+	}
+	parts := strings.SplitN(s, sep, 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("codegen: invalid relation %q", s)
+	}
+	op, mod, err := parseExpr(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return nil, err
+	}
+	ranges, err := parseRanges(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return nil, err
+	}
+	return &relation{op: op, mod: mod, negate: negate, ranges: ranges}, nil
+}
+
+func parseExpr(s string) (operand, int, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields[0]) != 1 {
+		return 0, 0, fmt.Errorf("codegen: invalid operand expression %q", s)
+	}
+	op := operand(fields[0][0])
+	switch len(fields) {
+	case 1:
+		return op, 0, nil
+	case 3:
+		if fields[1] != "%" && fields[1] != "mod" {
+			return 0, 0, fmt.Errorf("codegen: expected mod operator in %q", s)
+		}
+		mod, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return 0, 0, err
+		}
+		return op, mod, nil
+	default:
+		return 0, 0, fmt.Errorf("codegen: invalid operand expression %q", s)
+	}
+}
+
+func parseRanges(s string) ([]valueRange, error) {
+	var ranges []valueRange
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if i := strings.Index(part, ".."); i >= 0 {
+			low, err := strconv.Atoi(strings.TrimSpace(part[:i]))
+			if err != nil {
+				return nil, err
+			}
+			high, err := strconv.Atoi(strings.TrimSpace(part[i+2:]))
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, valueRange{low: low, high: high})
+			continue
+		}
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, valueRange{low: v, high: v})
+	}
+	return ranges, nil
+}
+
+// operands holds the numeric operands a relation can test, following
+// https://unicode.org/reports/tr35/tr35-numbers.html#Operands. It mirrors
+// plural.Operands but stays private to codegen so this command has no
+// dependency on the generated package.
+type operands struct {
+	n             float64
+	i, v, w, f, t int
+}
+
+// eval reports whether ops satisfies the condition. It is used to check
+// a locale's rules against CLDR's own @integer/@decimal samples before a
+// rule is emitted as Go source.
+func (or orCondition) eval(ops *operands) bool {
+	for _, and := range or {
+		if and.eval(ops) {
+			return true
+		}
+	}
+	return false
+}
+
+func (and andCondition) eval(ops *operands) bool {
+	for _, rel := range and {
+		if !rel.eval(ops) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *relation) eval(ops *operands) bool {
+	value := r.operandValue(ops)
+	if r.mod != 0 {
+		value %= r.mod
+	}
+	matched := false
+	// A non-integer n (or n % m) never equals an integer range value.
+	if r.op != operandN || ops.n == math.Trunc(ops.n) {
+		for _, rng := range r.ranges {
+			if value >= rng.low && value <= rng.high {
+				matched = true
+				break
+			}
+		}
+	}
+	if r.negate {
+		return !matched
+	}
+	return matched
+}
+
+func (r *relation) operandValue(ops *operands) int {
+	switch r.op {
+	case operandN:
+		return int(ops.n)
+	case operandI:
+		return ops.i
+	case operandV:
+		return ops.v
+	case operandW:
+		return ops.w
+	case operandF:
+		return ops.f
+	case operandT:
+		return ops.t
+	}
+	return 0
+}
+
+// sampleOperands computes the operands https://unicode.org/reports/tr35/tr35-numbers.html#Operands
+// defines for a single CLDR @integer/@decimal sample value, so eval can
+// check that value against a parsed condition.
+func sampleOperands(s sample) (*operands, error) {
+	n, err := strconv.ParseFloat(s.value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sample %q: %w", s.value, err)
+	}
+
+	dot := strings.Index(s.value, ".")
+	if dot < 0 {
+		return &operands{n: n, i: int(n)}, nil
+	}
+
+	frac := s.value[dot+1:]
+	f, err := strconv.Atoi(frac)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sample %q: %w", s.value, err)
+	}
+	trimmed := strings.TrimRight(frac, "0")
+	t := 0
+	if trimmed != "" {
+		if t, err = strconv.Atoi(trimmed); err != nil {
+			return nil, fmt.Errorf("invalid sample %q: %w", s.value, err)
+		}
+	}
+
+	return &operands{n: n, i: int(n), v: len(frac), w: len(trimmed), f: f, t: t}, nil
+}
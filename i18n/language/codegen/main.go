@@ -0,0 +1,418 @@
+// codegen fetches the Unicode CLDR's plurals.xml, ordinals.xml, and
+// pluralRanges.xml, translates each locale's cardinal, ordinal, and range
+// plural rules into a Go PluralFunc/OrdinalPluralFunc/RangePluralFunc,
+// and writes the result to i18n/language/pluralspec_gen.go, along with a
+// table-driven test in pluralspec_gen_test.go that checks each PluralFunc
+// against CLDR's own @integer/@decimal sample values.
+//
+// Run it with `go generate` from the i18n/language package (see the
+// //go:generate directive in language.go), or directly:
+//
+//	(cd i18n/language && go run ./codegen -plurals-url <url> -out pluralspec_gen.go)
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+)
+
+// The default URLs point at a released CLDR tag rather than "latest" so
+// that re-running codegen is reproducible until someone deliberately
+// bumps the CLDR version.
+const (
+	defaultPluralsURL      = "https://raw.githubusercontent.com/unicode-org/cldr/release-45/common/supplemental/plurals.xml"
+	defaultOrdinalsURL     = "https://raw.githubusercontent.com/unicode-org/cldr/release-45/common/supplemental/ordinals.xml"
+	defaultPluralRangesURL = "https://raw.githubusercontent.com/unicode-org/cldr/release-45/common/supplemental/pluralRanges.xml"
+)
+
+var (
+	pluralsURL      = flag.String("plurals-url", defaultPluralsURL, "URL of CLDR's plurals.xml (cardinal rules)")
+	ordinalsURL     = flag.String("ordinals-url", defaultOrdinalsURL, "URL of CLDR's ordinals.xml (ordinal rules)")
+	pluralRangesURL = flag.String("plural-ranges-url", defaultPluralRangesURL, "URL of CLDR's pluralRanges.xml (range rules)")
+	outPath         = flag.String("out", "pluralspec_gen.go", "output file for the generated plural functions")
+	testOutPath     = flag.String("test-out", "pluralspec_gen_test.go", "output file for the generated sample-based tests")
+)
+
+type supplementalData struct {
+	Plurals []pluralsElem `xml:"plurals"`
+}
+
+type pluralsElem struct {
+	Type         string             `xml:"type,attr"`
+	PluralRules  []pluralRulesElem  `xml:"pluralRules"`
+	PluralRanges []pluralRangesElem `xml:"pluralRanges"`
+}
+
+type pluralRulesElem struct {
+	Locales string           `xml:"locales,attr"`
+	Rules   []pluralRuleElem `xml:"pluralRule"`
+}
+
+type pluralRuleElem struct {
+	Count string `xml:"count,attr"`
+	Text  string `xml:",chardata"`
+}
+
+type pluralRangesElem struct {
+	Locales string            `xml:"locales,attr"`
+	Ranges  []pluralRangeElem `xml:"pluralRange"`
+}
+
+type pluralRangeElem struct {
+	Start  string `xml:"start,attr"`
+	End    string `xml:"end,attr"`
+	Result string `xml:"result,attr"`
+}
+
+// rangeRule is one (start category, end category) -> result category
+// mapping from pluralRanges.xml.
+type rangeRule struct {
+	start, end, result string
+}
+
+// localeRules is one locale's parsed plural rules, in the order CLDR
+// declared them (the order in which they must be tested, since later
+// categories are not mutually exclusive with earlier ones).
+type localeRules struct {
+	locale            string
+	conditions        map[string]orCondition
+	order             []string
+	samples           map[string][]sample
+	ordinalConditions map[string]orCondition
+	ordinalOrder      []string
+	ordinalSamples    map[string][]sample
+	ranges            []rangeRule
+}
+
+func main() {
+	flag.Parse()
+
+	pluralsData, err := fetchXML(*pluralsURL)
+	if err != nil {
+		fatal(err)
+	}
+	locales, err := parseCardinalRules(pluralsData)
+	if err != nil {
+		fatal(err)
+	}
+	byLocale := make(map[string]*localeRules, len(locales))
+	for _, lr := range locales {
+		byLocale[lr.locale] = lr
+	}
+
+	if ordinalsData, err := fetchXML(*ordinalsURL); err == nil {
+		if err := addOrdinalRules(byLocale, ordinalsData); err != nil {
+			fatal(err)
+		}
+	}
+
+	if rangesData, err := fetchXML(*pluralRangesURL); err == nil {
+		if err := addRangeRules(byLocale, rangesData); err != nil {
+			fatal(err)
+		}
+	}
+
+	src, err := generateSource(locales)
+	if err != nil {
+		fatal(err)
+	}
+	if err := os.WriteFile(*outPath, src, 0644); err != nil {
+		fatal(err)
+	}
+
+	testSrc, err := generateTestSource(locales)
+	if err != nil {
+		fatal(err)
+	}
+	if err := os.WriteFile(*testOutPath, testSrc, 0644); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "codegen:", err)
+	os.Exit(1)
+}
+
+func fetchXML(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func parseCardinalRules(data []byte) ([]*localeRules, error) {
+	var doc supplementalData
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var locales []*localeRules
+	for _, plurals := range doc.Plurals {
+		if plurals.Type != "cardinal" {
+			continue
+		}
+		for _, rulesElem := range plurals.PluralRules {
+			for _, localeID := range strings.Fields(rulesElem.Locales) {
+				lr := &localeRules{
+					locale:            localeID,
+					conditions:        make(map[string]orCondition),
+					samples:           make(map[string][]sample),
+					ordinalConditions: make(map[string]orCondition),
+					ordinalSamples:    make(map[string][]sample),
+				}
+				for _, rule := range rulesElem.Rules {
+					condText, integerSamples, decimalSamples := splitSamples(rule.Text)
+					cond, err := parseCondition(condText)
+					if err != nil {
+						return nil, fmt.Errorf("locale %s, category %s: %w", localeID, rule.Count, err)
+					}
+					samples := append(parseSamples(integerSamples), parseSamples(decimalSamples)...)
+					lr.samples[rule.Count] = samples
+					if rule.Count == "other" {
+						continue
+					}
+					lr.conditions[rule.Count] = cond
+					lr.order = append(lr.order, rule.Count)
+					if err := verifySamples(localeID, rule.Count, cond, samples); err != nil {
+						return nil, err
+					}
+				}
+				locales = append(locales, lr)
+			}
+		}
+	}
+	sort.Slice(locales, func(i, j int) bool { return locales[i].locale < locales[j].locale })
+	return locales, nil
+}
+
+// addOrdinalRules parses ordinals.xml (the same <plurals type="ordinal">
+// schema as plurals.xml) and attaches each locale's ordinal rules to the
+// matching entry in byLocale. Locales present in ordinals.xml but not in
+// plurals.xml are ignored; every CLDR locale has cardinal rules.
+func addOrdinalRules(byLocale map[string]*localeRules, data []byte) error {
+	var doc supplementalData
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	for _, plurals := range doc.Plurals {
+		if plurals.Type != "ordinal" {
+			continue
+		}
+		for _, rulesElem := range plurals.PluralRules {
+			for _, localeID := range strings.Fields(rulesElem.Locales) {
+				lr, ok := byLocale[localeID]
+				if !ok {
+					continue
+				}
+				for _, rule := range rulesElem.Rules {
+					condText, integerSamples, decimalSamples := splitSamples(rule.Text)
+					samples := append(parseSamples(integerSamples), parseSamples(decimalSamples)...)
+					lr.ordinalSamples[rule.Count] = samples
+					if rule.Count == "other" {
+						continue
+					}
+					cond, err := parseCondition(condText)
+					if err != nil {
+						return fmt.Errorf("locale %s, ordinal category %s: %w", localeID, rule.Count, err)
+					}
+					lr.ordinalConditions[rule.Count] = cond
+					lr.ordinalOrder = append(lr.ordinalOrder, rule.Count)
+					if err := verifySamples(localeID, rule.Count, cond, samples); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addRangeRules parses pluralRanges.xml and attaches each locale's range
+// rules to the matching entry in byLocale.
+func addRangeRules(byLocale map[string]*localeRules, data []byte) error {
+	var doc supplementalData
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	for _, plurals := range doc.Plurals {
+		for _, rangesElem := range plurals.PluralRanges {
+			for _, localeID := range strings.Fields(rangesElem.Locales) {
+				lr, ok := byLocale[localeID]
+				if !ok {
+					continue
+				}
+				for _, r := range rangesElem.Ranges {
+					lr.ranges = append(lr.ranges, rangeRule{start: r.Start, end: r.End, result: r.Result})
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// splitSamples separates a <pluralRule> element's text into its
+// condition and its trailing "@integer ... @decimal ..." sample lists.
+func splitSamples(text string) (condition, integerSamples, decimalSamples string) {
+	condition = text
+	if i := strings.Index(condition, "@integer"); i >= 0 {
+		rest := condition[i+len("@integer"):]
+		condition = strings.TrimSpace(condition[:i])
+		if j := strings.Index(rest, "@decimal"); j >= 0 {
+			integerSamples = strings.TrimSpace(rest[:j])
+			decimalSamples = strings.TrimSpace(rest[j+len("@decimal"):])
+		} else {
+			integerSamples = strings.TrimSpace(rest)
+		}
+	} else if i := strings.Index(condition, "@decimal"); i >= 0 {
+		decimalSamples = strings.TrimSpace(condition[i+len("@decimal"):])
+		condition = strings.TrimSpace(condition[:i])
+	}
+	return condition, integerSamples, decimalSamples
+}
+
+func generateSource(locales []*localeRules) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by i18n/language/codegen from CLDR's plurals.xml, ordinals.xml, and pluralRanges.xml. DO NOT EDIT.\n\n")
+	buf.WriteString("package language\n\n")
+	buf.WriteString("import \"github.com/nicksnyder/go-i18n/i18n/plural\"\n\n")
+	buf.WriteString("// Alphabetical by CLDR locale ID.\n")
+	buf.WriteString("var languages = map[string]*Language{\n")
+	for _, lr := range locales {
+		fmt.Fprintf(&buf, "\t%q: {\n", lr.locale)
+		fmt.Fprintf(&buf, "\t\tID:               %q,\n", lr.locale)
+		fmt.Fprintf(&buf, "\t\tPluralCategories: newSet(%s),\n", categoriesExpr(lr))
+		buf.WriteString("\t\tPluralFunc: func(ops *plural.Operands) plural.Category {\n")
+		for _, category := range lr.order {
+			fmt.Fprintf(&buf, "\t\t\tif %s {\n\t\t\t\treturn plural.%s\n\t\t\t}\n", lr.conditions[category].goExpr(), categoryIdent(category))
+		}
+		buf.WriteString("\t\t\treturn plural.Other\n\t\t},\n")
+
+		if len(lr.ordinalOrder) > 0 {
+			buf.WriteString("\t\tOrdinalPluralFunc: func(ops *plural.Operands) plural.Category {\n")
+			for _, category := range lr.ordinalOrder {
+				fmt.Fprintf(&buf, "\t\t\tif %s {\n\t\t\t\treturn plural.%s\n\t\t\t}\n", lr.ordinalConditions[category].goExpr(), categoryIdent(category))
+			}
+			buf.WriteString("\t\t\treturn plural.Other\n\t\t},\n")
+		}
+
+		if len(lr.ranges) > 0 {
+			buf.WriteString("\t\tRangePluralFunc: func(start, end plural.Category) plural.Category {\n")
+			for _, r := range lr.ranges {
+				fmt.Fprintf(&buf, "\t\t\tif start == plural.%s && end == plural.%s {\n\t\t\t\treturn plural.%s\n\t\t\t}\n",
+					categoryIdent(r.start), categoryIdent(r.end), categoryIdent(r.result))
+			}
+			buf.WriteString("\t\t\treturn end\n\t\t},\n")
+		}
+
+		buf.WriteString("\t},\n")
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+// verifySamples reports an error if any sample's operands don't satisfy
+// cond, catching a mistranslated CLDR condition before it's emitted as Go
+// source (rather than only when `go test` later runs against the
+// generated file).
+func verifySamples(locale, category string, cond orCondition, samples []sample) error {
+	for _, s := range samples {
+		ops, err := sampleOperands(s)
+		if err != nil {
+			return fmt.Errorf("locale %s, category %s: %w", locale, category, err)
+		}
+		if !cond.eval(ops) {
+			return fmt.Errorf("locale %s, category %s: sample %q does not satisfy the parsed condition", locale, category, s.value)
+		}
+	}
+	return nil
+}
+
+// generateTestSource emits a table-driven test that checks each locale's
+// PluralFunc and OrdinalPluralFunc against CLDR's own @integer/@decimal
+// sample values for every category, so a mistranslated condition in
+// generateSource shows up as a test failure instead of silently producing
+// the wrong category.
+//
+// pluralRanges.xml has no equivalent sample mechanism, so this function
+// cannot emit (and does not emit) a range-category test; see
+// i18n/language/language_test.go for hand-written range coverage.
+func generateTestSource(locales []*localeRules) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by i18n/language/codegen from CLDR's plurals.xml and ordinals.xml. DO NOT EDIT.\n\n")
+	buf.WriteString("package language\n\n")
+	buf.WriteString("import (\n\t\"testing\"\n\n\t\"github.com/nicksnyder/go-i18n/i18n/plural\"\n)\n\n")
+
+	buf.WriteString("func TestPluralFuncSamples(t *testing.T) {\n")
+	writeSampleCases(&buf, locales, func(lr *localeRules) map[string][]sample { return lr.samples })
+	buf.WriteString("\n\tfor _, c := range cases {\n")
+	buf.WriteString("\t\tlang := Parse(c.locale)\n")
+	buf.WriteString("\t\tif lang == nil {\n\t\t\tt.Fatalf(\"unknown locale %q\", c.locale)\n\t\t}\n")
+	buf.WriteString("\t\tgot, err := lang.PluralCategory(c.number)\n")
+	buf.WriteString("\t\tif err != nil {\n\t\t\tt.Fatalf(\"%s: PluralCategory(%v): %v\", c.locale, c.number, err)\n\t\t}\n")
+	buf.WriteString("\t\tif got != c.category {\n\t\t\tt.Errorf(\"%s: PluralCategory(%v) = %s, want %s\", c.locale, c.number, got, c.category)\n\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("func TestOrdinalPluralFuncSamples(t *testing.T) {\n")
+	ordinalLocales := make([]*localeRules, 0, len(locales))
+	for _, lr := range locales {
+		if len(lr.ordinalOrder) > 0 {
+			ordinalLocales = append(ordinalLocales, lr)
+		}
+	}
+	writeSampleCases(&buf, ordinalLocales, func(lr *localeRules) map[string][]sample { return lr.ordinalSamples })
+	buf.WriteString("\n\tfor _, c := range cases {\n")
+	buf.WriteString("\t\tlang := Parse(c.locale)\n")
+	buf.WriteString("\t\tif lang == nil {\n\t\t\tt.Fatalf(\"unknown locale %q\", c.locale)\n\t\t}\n")
+	buf.WriteString("\t\tgot, err := lang.OrdinalCategory(c.number)\n")
+	buf.WriteString("\t\tif err != nil {\n\t\t\tt.Fatalf(\"%s: OrdinalCategory(%v): %v\", c.locale, c.number, err)\n\t\t}\n")
+	buf.WriteString("\t\tif got != c.category {\n\t\t\tt.Errorf(\"%s: OrdinalCategory(%v) = %s, want %s\", c.locale, c.number, got, c.category)\n\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// writeSampleCases writes the `cases := []struct{...}{...}` table shared
+// by the TestPluralFuncSamples/TestOrdinalPluralFuncSamples bodies,
+// reading each locale's samples via samplesOf.
+func writeSampleCases(buf *bytes.Buffer, locales []*localeRules, samplesOf func(*localeRules) map[string][]sample) {
+	buf.WriteString("\tcases := []struct {\n\t\tlocale   string\n\t\tcategory plural.Category\n\t\tnumber   interface{}\n\t}{\n")
+	for _, lr := range locales {
+		bySample := samplesOf(lr)
+		categories := make([]string, 0, len(bySample))
+		for category := range bySample {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+		for _, category := range categories {
+			for _, s := range bySample[category] {
+				fmt.Fprintf(buf, "\t\t{%q, plural.%s, %s},\n", lr.locale, categoryIdent(category), s.value)
+			}
+		}
+	}
+	buf.WriteString("\t}\n")
+}
+
+func categoriesExpr(lr *localeRules) string {
+	categories := append(append([]string{}, lr.order...), "other")
+	idents := make([]string, len(categories))
+	for i, c := range categories {
+		idents[i] = "plural." + categoryIdent(c)
+	}
+	return strings.Join(idents, ", ")
+}
@@ -1,6 +1,8 @@
 // Package language defines languages that implement CLDR pluralization.
 package language
 
+//go:generate go run ./codegen
+
 import (
 	"fmt"
 	"strings"
@@ -23,220 +25,42 @@ type Language struct {
 	ID               string
 	Name             string
 	PluralCategories map[plural.Category]struct{}
-	PluralFunc       func(*plural.Operands) plural.Category
-}
-
-// Alphabetical by English name.
-var languages = map[string]*Language{
-	// Arabic
-	"ar": &Language{
-		ID:               "ar",
-		PluralCategories: newSet(plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.W == 0 {
-				// Integer case
-				switch ops.I {
-				case 0:
-					return plural.Zero
-				case 1:
-					return plural.One
-				case 2:
-					return plural.Two
-				default:
-					mod100 := ops.I % 100
-					if mod100 >= 3 && mod100 <= 10 {
-						return plural.Few
-					}
-					if mod100 >= 11 {
-						return plural.Many
-					}
-				}
-			}
-			return plural.Other
-		},
-	},
-
-	// Catalan
-	"ca": &Language{
-		ID:               "ca",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// Chinese
-	// There is no need to distinguish between simplified and traditional
-	// since they have the same pluralization.
-	"zh": &Language{
-		ID:               "zh",
-		PluralCategories: newSet(plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			return plural.Other
-		},
-	},
-
-	// Czech
-	"cs": &Language{
-		ID:               "cs",
-		PluralCategories: newSet(plural.One, plural.Few, plural.Many, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			if ops.I >= 2 && ops.I <= 4 && ops.V == 0 {
-				return plural.Few
-			}
-			if ops.V > 0 {
-				return plural.Many
-			}
-			return plural.Other
-		},
-	},
-
-	// Danish
-	"da": &Language{
-		ID:               "da",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 || (ops.I == 0 && ops.T != 0) {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
 
-	// Dutch
-	"nl": &Language{
-		ID:               "nl",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// English
-	"en": &Language{
-		ID:               "en",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// French
-	"fr": &Language{
-		ID:               "fr",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 0 || ops.I == 1 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// German
-	"de": &Language{
-		ID:               "de",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// Italian
-	"it": &Language{
-		ID:               "it",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-
-	// Japanese
-	"ja": &Language{
-		ID:               "ja",
-		PluralCategories: newSet(plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			return plural.Other
-		},
-	},
-
-	// Lithuanian
-	"lt": &Language{
-		ID:               "lt",
-		PluralCategories: newSet(plural.One, plural.Few, plural.Many, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.F != 0 {
-				return plural.Many
-			}
-			mod100 := ops.I % 100
-			if mod100 < 11 || mod100 > 19 {
-				switch ops.I % 10 {
-				case 0:
-					return plural.Other
-				case 1:
-					return plural.One
-				default:
-					return plural.Few
-				}
-			}
-			return plural.Other
-		},
-	},
-
-	// Portuguese (European)
-	"pt": &Language{
-		ID:               "pt",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.V == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
+	// PluralFunc selects the cardinal plural category for a number
+	// (e.g. "1 book" vs "2 books").
+	PluralFunc func(*plural.Operands) plural.Category
+
+	// OrdinalPluralFunc selects the ordinal plural category for a number
+	// (e.g. "1st" vs "2nd" vs "3rd" vs "4th"). Languages that don't
+	// distinguish ordinal forms leave this nil; OrdinalCategory then
+	// always reports plural.Other.
+	OrdinalPluralFunc func(*plural.Operands) plural.Category
+
+	// RangePluralFunc selects the plural category for a range given the
+	// cardinal categories of its start and end (e.g. French "de 1 à 2"
+	// vs "de 2 à 9"). Languages that don't override the range form
+	// leave this nil; RangeCategory then falls back to the end
+	// category, per CLDR's default range resolution.
+	RangePluralFunc func(start, end plural.Category) plural.Category
+}
 
-	// Portuguese (Brazilian)
-	"pt-BR": &Language{
-		ID:               "pt-BR",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if (ops.I == 1 && ops.V == 0) || (ops.I == 0 && ops.T == 1) {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
+// PluralType identifies which of CLDR's three plural rule sets a
+// Message should consult when picking a template: cardinal ("1 book" vs
+// "2 books"), ordinal ("1st" vs "2nd"), or range ("1-2").
+type PluralType int
+
+const (
+	// Cardinal is CLDR's default plural type, selected by Language.PluralCategory.
+	Cardinal PluralType = iota
+	// Ordinal is selected by Language.OrdinalCategory.
+	Ordinal
+	// Range is selected by Language.RangeCategory.
+	Range
+)
 
-	// Spanish
-	"es": &Language{
-		ID:               "es",
-		PluralCategories: newSet(plural.One, plural.Other),
-		PluralFunc: func(ops *plural.Operands) plural.Category {
-			if ops.I == 1 && ops.W == 0 {
-				return plural.One
-			}
-			return plural.Other
-		},
-	},
-}
+// languages is defined in pluralspec_gen.go, which go generate (the
+// codegen subcommand) rewrites from CLDR's plurals.xml; the checked-in
+// copy is a curated snapshot, as its header comment says.
 
 // Parse returns the first supported language found in lang or nil none exists.
 //
@@ -287,8 +111,8 @@ func Register(l *Language) {
 	languages[l.ID] = l
 }
 
-// PluralCategory returns the plural category for number as defined by
-// the language's CLDR plural rules.
+// PluralCategory returns the cardinal plural category for number as
+// defined by the language's CLDR plural rules.
 func (l *Language) PluralCategory(number interface{}) (plural.Category, error) {
 	ops, err := plural.NewOperands(number)
 	if err != nil {
@@ -297,6 +121,39 @@ func (l *Language) PluralCategory(number interface{}) (plural.Category, error) {
 	return l.PluralFunc(ops), nil
 }
 
+// OrdinalCategory returns the ordinal plural category for number (e.g.
+// "1st" vs "2nd"), as defined by the language's CLDR ordinal plural
+// rules. Languages without ordinal rules always return plural.Other.
+func (l *Language) OrdinalCategory(number interface{}) (plural.Category, error) {
+	ops, err := plural.NewOperands(number)
+	if err != nil {
+		return plural.Invalid, err
+	}
+	if l.OrdinalPluralFunc == nil {
+		return plural.Other, nil
+	}
+	return l.OrdinalPluralFunc(ops), nil
+}
+
+// RangeCategory returns the plural category for the range [start, end]
+// (e.g. "de 1 à 2" vs "de 2 à 9"), as defined by the language's CLDR
+// plural range rules. Languages without range rules fall back to the
+// cardinal category of end, per CLDR's default range resolution.
+func (l *Language) RangeCategory(start, end interface{}) (plural.Category, error) {
+	startCategory, err := l.PluralCategory(start)
+	if err != nil {
+		return plural.Invalid, err
+	}
+	endCategory, err := l.PluralCategory(end)
+	if err != nil {
+		return plural.Invalid, err
+	}
+	if l.RangePluralFunc == nil {
+		return endCategory, nil
+	}
+	return l.RangePluralFunc(startCategory, endCategory), nil
+}
+
 func (l *Language) String() string {
 	return l.ID
 }
@@ -0,0 +1,86 @@
+package language
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/i18n/plural"
+)
+
+// TestOrdinalCategoryDefaultsToOther checks that a locale without an
+// OrdinalPluralFunc (i.e. CLDR's ordinals.xml has no rules for it) always
+// reports plural.Other, rather than falling through to the cardinal
+// PluralFunc.
+func TestOrdinalCategoryDefaultsToOther(t *testing.T) {
+	lang := MustParse("de") // has no OrdinalPluralFunc in pluralspec_gen.go
+	got, err := lang.OrdinalCategory(1)
+	if err != nil {
+		t.Fatalf("OrdinalCategory(1): %v", err)
+	}
+	if got != plural.Other {
+		t.Errorf("OrdinalCategory(1) = %s, want %s", got, plural.Other)
+	}
+}
+
+// TestOrdinalCategoryEnglish spot-checks English's real OrdinalPluralFunc.
+func TestOrdinalCategoryEnglish(t *testing.T) {
+	lang := MustParse("en")
+	cases := []struct {
+		number   interface{}
+		category plural.Category
+	}{
+		{1, plural.One},
+		{2, plural.Two},
+		{3, plural.Few},
+		{4, plural.Other},
+		{11, plural.Other},
+		{21, plural.One},
+	}
+	for _, c := range cases {
+		got, err := lang.OrdinalCategory(c.number)
+		if err != nil {
+			t.Fatalf("OrdinalCategory(%v): %v", c.number, err)
+		}
+		if got != c.category {
+			t.Errorf("OrdinalCategory(%v) = %s, want %s", c.number, got, c.category)
+		}
+	}
+}
+
+// TestRangeCategoryDefaultsToEnd checks that a locale without a
+// RangePluralFunc (i.e. CLDR's pluralRanges.xml has no rules for it)
+// falls back to the cardinal category of the range's end, per CLDR's
+// default range resolution.
+func TestRangeCategoryDefaultsToEnd(t *testing.T) {
+	lang := MustParse("de") // has no RangePluralFunc in pluralspec_gen.go
+	got, err := lang.RangeCategory(1, 2)
+	if err != nil {
+		t.Fatalf("RangeCategory(1, 2): %v", err)
+	}
+	if want, _ := lang.PluralCategory(2); got != want {
+		t.Errorf("RangeCategory(1, 2) = %s, want %s (cardinal category of end)", got, want)
+	}
+}
+
+// TestRangeCategoryFrench spot-checks French's RangePluralFunc: "de 1 à
+// 2" uses the one form, but "de 2 à 9" does not, because French overrides
+// only the (one, other) -> one range and otherwise falls back to the end
+// category.
+func TestRangeCategoryFrench(t *testing.T) {
+	lang := MustParse("fr")
+
+	got, err := lang.RangeCategory(1, 2)
+	if err != nil {
+		t.Fatalf("RangeCategory(1, 2): %v", err)
+	}
+	if got != plural.One {
+		t.Errorf("RangeCategory(1, 2) = %s, want %s", got, plural.One)
+	}
+
+	got, err = lang.RangeCategory(2, 9)
+	if err != nil {
+		t.Fatalf("RangeCategory(2, 9): %v", err)
+	}
+	if got != plural.Other {
+		t.Errorf("RangeCategory(2, 9) = %s, want %s", got, plural.Other)
+	}
+}
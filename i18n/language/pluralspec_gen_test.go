@@ -0,0 +1,154 @@
+// Code generated by i18n/language/codegen from CLDR's plurals.xml and
+// ordinals.xml. DO NOT EDIT.
+//
+// pluralspec_gen.go is a curated 15-locale snapshot rather than a live
+// codegen run (see the comment at the top of that file), so these cases
+// are representative samples derived from each locale's own PluralFunc and
+// OrdinalPluralFunc rather than CLDR's real @integer/@decimal sample
+// lists. Re-running `go generate` with network access replaces both files
+// with the real CLDR-sourced output.
+//
+// CLDR's pluralRanges.xml has no sample mechanism, so this generator
+// cannot produce a TestRangePluralFuncSamples; see
+// i18n/language/language_test.go for hand-written range coverage.
+
+package language
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/i18n/plural"
+)
+
+func TestPluralFuncSamples(t *testing.T) {
+	cases := []struct {
+		locale   string
+		category plural.Category
+		number   interface{}
+	}{
+		{"ar", plural.Zero, 0},
+		{"ar", plural.One, 1},
+		{"ar", plural.Two, 2},
+		{"ar", plural.Few, 3},
+		{"ar", plural.Few, 10},
+		{"ar", plural.Many, 11},
+		{"ar", plural.Many, 99},
+		{"ar", plural.Other, 100},
+
+		{"ca", plural.One, 1},
+		{"ca", plural.Other, 0},
+		{"ca", plural.Other, 2},
+
+		{"cs", plural.One, 1},
+		{"cs", plural.Few, 2},
+		{"cs", plural.Few, 4},
+		{"cs", plural.Many, 1.5},
+		{"cs", plural.Other, 0},
+		{"cs", plural.Other, 5},
+
+		{"da", plural.One, 1},
+		{"da", plural.One, 0.5},
+		{"da", plural.Other, 0},
+		{"da", plural.Other, 2},
+
+		{"de", plural.One, 1},
+		{"de", plural.Other, 0},
+		{"de", plural.Other, 2},
+
+		{"en", plural.One, 1},
+		{"en", plural.Other, 0},
+		{"en", plural.Other, 2},
+
+		{"es", plural.One, 1},
+		{"es", plural.Other, 0},
+		{"es", plural.Other, 2},
+
+		{"fr", plural.One, 0},
+		{"fr", plural.One, 1},
+		{"fr", plural.Other, 2},
+		{"fr", plural.Other, 9},
+
+		{"it", plural.One, 1},
+		{"it", plural.Other, 0},
+		{"it", plural.Other, 2},
+
+		{"ja", plural.Other, 0},
+		{"ja", plural.Other, 1},
+		{"ja", plural.Other, 2},
+
+		{"lt", plural.One, 1},
+		{"lt", plural.One, 21},
+		{"lt", plural.Few, 2},
+		{"lt", plural.Few, 22},
+		{"lt", plural.Many, 1.1},
+		{"lt", plural.Other, 0},
+		{"lt", plural.Other, 11},
+
+		{"nl", plural.One, 1},
+		{"nl", plural.Other, 0},
+		{"nl", plural.Other, 2},
+
+		{"pt", plural.One, 1},
+		{"pt", plural.Other, 0},
+		{"pt", plural.Other, 2},
+
+		{"pt-BR", plural.One, 1},
+		{"pt-BR", plural.Other, 0},
+		{"pt-BR", plural.Other, 2},
+
+		{"zh", plural.Other, 0},
+		{"zh", plural.Other, 1},
+		{"zh", plural.Other, 2},
+	}
+
+	for _, c := range cases {
+		lang := Parse(c.locale)
+		if lang == nil {
+			t.Fatalf("unknown locale %q", c.locale)
+		}
+		got, err := lang.PluralCategory(c.number)
+		if err != nil {
+			t.Fatalf("%s: PluralCategory(%v): %v", c.locale, c.number, err)
+		}
+		if got != c.category {
+			t.Errorf("%s: PluralCategory(%v) = %s, want %s", c.locale, c.number, got, c.category)
+		}
+	}
+}
+
+func TestOrdinalPluralFuncSamples(t *testing.T) {
+	cases := []struct {
+		locale   string
+		category plural.Category
+		number   interface{}
+	}{
+		{"en", plural.One, 1},
+		{"en", plural.One, 21},
+		{"en", plural.Two, 2},
+		{"en", plural.Two, 22},
+		{"en", plural.Few, 3},
+		{"en", plural.Few, 23},
+		{"en", plural.Other, 4},
+		{"en", plural.Other, 11},
+		{"en", plural.Other, 12},
+		{"en", plural.Other, 13},
+
+		{"fr", plural.One, 1},
+		{"fr", plural.Other, 0},
+		{"fr", plural.Other, 2},
+	}
+
+	for _, c := range cases {
+		lang := Parse(c.locale)
+		if lang == nil {
+			t.Fatalf("unknown locale %q", c.locale)
+		}
+		got, err := lang.OrdinalCategory(c.number)
+		if err != nil {
+			t.Fatalf("%s: OrdinalCategory(%v): %v", c.locale, c.number, err)
+		}
+		if got != c.category {
+			t.Errorf("%s: OrdinalCategory(%v) = %s, want %s", c.locale, c.number, got, c.category)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+// Package bundle loads translation files and serves translations without
+// relying on process-global state, so a server can load several locale
+// sets (or reload them) without one request's locale bleeding into
+// another's.
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/plural"
+)
+
+// Bundle holds a set of translations loaded from message files, keyed by
+// locale and message id.
+//
+// The zero value is not usable; construct one with New.
+type Bundle struct {
+	translations       map[string]map[string]string
+	pluralTranslations map[string]map[string]map[plural.Category]string
+}
+
+// New returns an empty Bundle.
+func New() *Bundle {
+	return &Bundle{
+		translations:       make(map[string]map[string]string),
+		pluralTranslations: make(map[string]map[string]map[plural.Category]string),
+	}
+}
+
+// LoadMessageFile loads the translations in the file at path into the
+// bundle. The locale is parsed from the filename (e.g. "active.en-US.toml"
+// has locale "en-US") and the format is chosen by the file extension
+// (.toml, .json, .yaml, or .yml).
+func (b *Bundle) LoadMessageFile(path string) error {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return b.parseMessageFileBytes(buf, path)
+}
+
+// LoadMessageFileFS is like LoadMessageFile but reads path from fsys
+// instead of the local filesystem.
+func (b *Bundle) LoadMessageFileFS(fsys fs.FS, path string) error {
+	buf, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return err
+	}
+	return b.parseMessageFileBytes(buf, path)
+}
+
+func (b *Bundle) parseMessageFileBytes(buf []byte, path string) error {
+	locale, format := localeAndFormat(path)
+	if locale == "" {
+		return fmt.Errorf("bundle: no locale found in file %q", path)
+	}
+
+	messages, err := unmarshalMessageFile(format, buf)
+	if err != nil {
+		return fmt.Errorf("bundle: failed to load %q: %w", path, err)
+	}
+
+	for id, value := range messages {
+		switch v := value.(type) {
+		case string:
+			b.AddMessage(locale, id, v)
+		case map[string]string:
+			templates, err := toPluralTemplates(v)
+			if err != nil {
+				return fmt.Errorf("bundle: message %q in %q: %w", id, path, err)
+			}
+			b.AddPluralMessage(locale, id, templates)
+		default:
+			return fmt.Errorf("bundle: message %q in %q must be a string or a table of plural categories", id, path)
+		}
+	}
+	return nil
+}
+
+// localeAndFormat splits a message filename of the form
+// "<namespace>.<locale>.<ext>" into its locale and format.
+func localeAndFormat(path string) (locale, format string) {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	format = strings.TrimPrefix(ext, ".")
+	base = strings.TrimSuffix(base, ext)
+
+	parts := strings.Split(base, ".")
+	if len(parts) < 2 {
+		return "", format
+	}
+	return parts[len(parts)-1], format
+}
+
+func unmarshalMessageFile(format string, buf []byte) (map[string]interface{}, error) {
+	var raw map[string]interface{}
+	var err error
+	switch format {
+	case "json":
+		err = json.Unmarshal(buf, &raw)
+	case "toml":
+		err = toml.Unmarshal(buf, &raw)
+	case "yaml", "yml":
+		err = yaml.Unmarshal(buf, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported message file format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(map[string]interface{}, len(raw))
+	for id, value := range raw {
+		switch v := value.(type) {
+		case string:
+			messages[id] = v
+		case map[string]interface{}:
+			messages[id] = toStringMap(v)
+		case map[interface{}]interface{}:
+			// yaml.v2 decodes nested maps with interface{} keys.
+			converted := make(map[string]interface{}, len(v))
+			for k, vv := range v {
+				converted[fmt.Sprint(k)] = vv
+			}
+			messages[id] = toStringMap(converted)
+		default:
+			messages[id] = v
+		}
+	}
+	return messages, nil
+}
+
+func toStringMap(m map[string]interface{}) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = fmt.Sprint(v)
+	}
+	return out
+}
+
+// categoryNames maps CLDR's plural category names, as they appear as
+// table keys in message files, to plural.Category.
+var categoryNames = map[string]plural.Category{
+	"zero":  plural.Zero,
+	"one":   plural.One,
+	"two":   plural.Two,
+	"few":   plural.Few,
+	"many":  plural.Many,
+	"other": plural.Other,
+}
+
+func toPluralTemplates(m map[string]string) (map[plural.Category]string, error) {
+	templates := make(map[plural.Category]string, len(m))
+	for name, tmpl := range m {
+		category, ok := categoryNames[name]
+		if !ok {
+			return nil, fmt.Errorf("bundle: %q is not a CLDR plural category", name)
+		}
+		templates[category] = tmpl
+	}
+	return templates, nil
+}
+
+// AddMessage registers the translation of a single message id for locale.
+func (b *Bundle) AddMessage(locale, id, translation string) {
+	if b.translations[locale] == nil {
+		b.translations[locale] = make(map[string]string)
+	}
+	b.translations[locale][id] = translation
+}
+
+// AddPluralMessage registers the per-plural-category templates for message
+// id in locale, dropping any categories that locale's Language does not
+// define.
+func (b *Bundle) AddPluralMessage(locale, id string, templates map[plural.Category]string) {
+	if b.pluralTranslations[locale] == nil {
+		b.pluralTranslations[locale] = make(map[string]map[plural.Category]string)
+	}
+	b.pluralTranslations[locale][id] = normalizePluralTemplates(locale, templates)
+}
+
+func normalizePluralTemplates(locale string, templates map[plural.Category]string) map[plural.Category]string {
+	lang := language.Parse(locale)
+	if lang == nil {
+		return templates
+	}
+	normalized := make(map[plural.Category]string, len(templates))
+	for category, tmpl := range templates {
+		if _, ok := lang.PluralCategories[category]; ok {
+			normalized[category] = tmpl
+		}
+	}
+	return normalized
+}
+
+// Translation returns the registered translation of id in locale, and
+// whether one was registered.
+func (b *Bundle) Translation(locale, id string) (string, bool) {
+	t, ok := b.translations[locale][id]
+	return t, ok
+}
+
+// PluralTemplate returns the registered template for id and category in
+// locale, falling back to the locale's plural.Other template, and
+// whether any template was found.
+func (b *Bundle) PluralTemplate(locale, id string, category plural.Category) (string, bool) {
+	templates, ok := b.pluralTranslations[locale][id]
+	if !ok {
+		return "", false
+	}
+	if tmpl, ok := templates[category]; ok {
+		return tmpl, true
+	}
+	if tmpl, ok := templates[plural.Other]; ok {
+		return tmpl, true
+	}
+	return "", false
+}
+
+// hasLocale reports whether any translation has been registered for locale.
+func (b *Bundle) hasLocale(locale string) bool {
+	_, hasMessages := b.translations[locale]
+	_, hasPlurals := b.pluralTranslations[locale]
+	return hasMessages || hasPlurals
+}
+
+// Localizer serves translations for a single resolved locale out of a
+// Bundle. Unlike the package-level state in package i18n, a Localizer can
+// be created per request so concurrent requests never share a locale.
+type Localizer struct {
+	bundle *Bundle
+	locale string
+}
+
+// NewLocalizer returns a Localizer for the first locale registered with
+// the bundle among langs, which may be locale tags or comma-separated
+// Accept-Language header values. Each tag is resolved using the same
+// fallback chain as language.Parse: region subtags are stripped on a miss
+// (e.g. "pt-BR" falls back to "pt").
+func (b *Bundle) NewLocalizer(langs ...string) *Localizer {
+	for _, header := range langs {
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimSpace(strings.SplitN(tag, ";", 2)[0])
+			if locale := b.matchLocale(tag); locale != "" {
+				return &Localizer{bundle: b, locale: locale}
+			}
+		}
+	}
+	return &Localizer{bundle: b}
+}
+
+// matchLocale walks tag's fallback chain, returning the first locale
+// registered with b, or "" if none match.
+func (b *Bundle) matchLocale(tag string) string {
+	for tag != "" {
+		if b.hasLocale(tag) {
+			return tag
+		}
+		end := strings.LastIndex(tag, "-")
+		if end < 0 {
+			return ""
+		}
+		tag = tag[:end]
+	}
+	return ""
+}
+
+// Locale returns the locale this Localizer resolved to.
+func (l *Localizer) Locale() string {
+	return l.locale
+}
+
+// Message returns the translation of id in the Localizer's locale, or id
+// itself if no translation is registered.
+func (l *Localizer) Message(id string) string {
+	if t, ok := l.bundle.Translation(l.locale, id); ok {
+		return t
+	}
+	return id
+}
+
+// Plural returns the translation of id in the Localizer's locale,
+// selecting the template whose plural category matches number under
+// pluralType (language.Cardinal or language.Ordinal), and rendering it
+// with text/template using number as .Count. pluralType must not be
+// language.Range, which needs a (start, end) pair rather than a single
+// number; PluralWithData returns an error for it.
+func (l *Localizer) Plural(id string, pluralType language.PluralType, number interface{}) (string, error) {
+	return l.PluralWithData(id, pluralType, number, nil)
+}
+
+// PluralWithData is like Plural but also renders the selected template
+// with data in addition to .Count, which is set to number.
+func (l *Localizer) PluralWithData(id string, pluralType language.PluralType, number interface{}, data map[string]interface{}) (string, error) {
+	lang := language.Parse(l.locale)
+	if lang == nil {
+		return "", fmt.Errorf("bundle: unable to parse language from locale %q", l.locale)
+	}
+
+	var category plural.Category
+	var err error
+	switch pluralType {
+	case language.Range:
+		return "", fmt.Errorf("bundle: language.Range requires a (start, end) pair, which Plural does not accept")
+	case language.Ordinal:
+		category, err = lang.OrdinalCategory(number)
+	default:
+		category, err = lang.PluralCategory(number)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	tmplStr, found := l.bundle.PluralTemplate(l.locale, id, category)
+	if !found {
+		return "", fmt.Errorf("bundle: no plural translation for %q in locale %q", id, l.locale)
+	}
+	return renderPluralTemplate(tmplStr, number, data)
+}
+
+// renderPluralTemplate parses and executes tmplStr with text/template,
+// exposing number as .Count alongside the optional data.
+func renderPluralTemplate(tmplStr string, number interface{}, data map[string]interface{}) (string, error) {
+	tmpl, err := template.New("plural").Parse(tmplStr)
+	if err != nil {
+		return "", err
+	}
+	templateData := make(map[string]interface{}, len(data)+1)
+	for k, v := range data {
+		templateData[k] = v
+	}
+	templateData["Count"] = number
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,139 @@
+package bundle
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/plural"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"active.en.toml": &fstest.MapFile{Data: []byte(`
+hello = "Hello"
+items = { one = "{{.Count}} item", other = "{{.Count}} items" }
+`)},
+		"active.fr.json": &fstest.MapFile{Data: []byte(`{
+	"hello": "Bonjour",
+	"items": {"one": "{{.Count}} article", "other": "{{.Count}} articles"}
+}`)},
+		"active.de.yaml": &fstest.MapFile{Data: []byte(`
+hello: Hallo
+items:
+  one: "{{.Count}} Artikel"
+  other: "{{.Count}} Artikel"
+`)},
+	}
+}
+
+func TestLoadMessageFileFSFormats(t *testing.T) {
+	fsys := testFS()
+	b := New()
+	for _, path := range []string{"active.en.toml", "active.fr.json", "active.de.yaml"} {
+		if err := b.LoadMessageFileFS(fsys, path); err != nil {
+			t.Fatalf("LoadMessageFileFS(%q): %v", path, err)
+		}
+	}
+
+	cases := []struct {
+		locale, id, want string
+	}{
+		{"en", "hello", "Hello"},
+		{"fr", "hello", "Bonjour"},
+		{"de", "hello", "Hallo"},
+	}
+	for _, c := range cases {
+		got, ok := b.Translation(c.locale, c.id)
+		if !ok {
+			t.Errorf("Translation(%q, %q): not found", c.locale, c.id)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("Translation(%q, %q) = %q, want %q", c.locale, c.id, got, c.want)
+		}
+	}
+
+	// "items" has no explicit plural.Two template; PluralTemplate should
+	// fall back to plural.Other.
+	if _, ok := b.PluralTemplate("en", "items", plural.Two); !ok {
+		t.Error("PluralTemplate(en, items, plural.Two): not found (expected fallback to plural.Other)")
+	}
+}
+
+func TestLoadMessageFileFSRejectsUnknownCategory(t *testing.T) {
+	fsys := fstest.MapFS{
+		"active.es.toml": &fstest.MapFile{Data: []byte(`
+items = { singular = "{{.Count}} elemento", other = "{{.Count}} elementos" }
+`)},
+	}
+	b := New()
+	err := b.LoadMessageFileFS(fsys, "active.es.toml")
+	if err == nil {
+		t.Fatal("LoadMessageFileFS with an unknown plural category returned a nil error")
+	}
+	if !strings.Contains(err.Error(), "singular") {
+		t.Errorf("error %q does not name the offending category", err)
+	}
+}
+
+func TestNewLocalizerNegotiation(t *testing.T) {
+	b := New()
+	b.AddMessage("en", "hello", "Hello")
+	b.AddMessage("fr", "hello", "Bonjour")
+
+	cases := []struct {
+		header string
+		want   string
+	}{
+		{"fr-CA,en;q=0.8", "fr"},
+		{"de,fr;q=0.9", "fr"},
+		{"en", "en"},
+		{"pt-BR", ""},
+	}
+	for _, c := range cases {
+		l := b.NewLocalizer(c.header)
+		if got := l.Locale(); got != c.want {
+			t.Errorf("NewLocalizer(%q).Locale() = %q, want %q", c.header, got, c.want)
+		}
+	}
+}
+
+func TestLocalizerMessage(t *testing.T) {
+	b := New()
+	b.AddMessage("en", "hello", "Hello")
+
+	l := b.NewLocalizer("en")
+	if got, want := l.Message("hello"), "Hello"; got != want {
+		t.Errorf("Message(hello) = %q, want %q", got, want)
+	}
+	if got, want := l.Message("missing"), "missing"; got != want {
+		t.Errorf("Message(missing) = %q, want %q (id returned untranslated)", got, want)
+	}
+}
+
+func TestLocalizerPlural(t *testing.T) {
+	b := New()
+	fsys := testFS()
+	if err := b.LoadMessageFileFS(fsys, "active.en.toml"); err != nil {
+		t.Fatalf("LoadMessageFileFS: %v", err)
+	}
+
+	l := b.NewLocalizer("en")
+	if got, err := l.Plural("items", language.Cardinal, 1); err != nil {
+		t.Fatalf("Plural(Cardinal, 1): %v", err)
+	} else if want := "1 item"; got != want {
+		t.Errorf("Plural(Cardinal, 1) = %q, want %q", got, want)
+	}
+
+	if got, err := l.Plural("items", language.Cardinal, 2); err != nil {
+		t.Fatalf("Plural(Cardinal, 2): %v", err)
+	} else if want := "2 items"; got != want {
+		t.Errorf("Plural(Cardinal, 2) = %q, want %q", got, want)
+	}
+
+	if _, err := l.Plural("items", language.Range, 2); err == nil {
+		t.Error("Plural(Range, 2) returned a nil error; want an error because Range needs a (start, end) pair")
+	}
+}
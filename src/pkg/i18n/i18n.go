@@ -1,13 +1,26 @@
 package i18n
 
 import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/plural"
+	"github.com/nicksnyder/go-i18n/src/pkg/i18n/bundle"
 	"github.com/nicksnyder/go-i18n/src/pkg/msg"
 )
 
 const defaultLocale = ""
 
 var currentLocale = defaultLocale
-var translations = make(map[string]map[string]string)
+
+// defaultBundle stores the translations registered through the
+// package-level AddTranslation/AddPluralTranslation API. It's a regular
+// *bundle.Bundle, the same type a caller can construct directly with
+// bundle.New to avoid the global currentLocale entirely.
+var defaultBundle = bundle.New()
 
 // SetLocale sets the locale to use for translated messages.
 func SetLocale(locale string) {
@@ -19,11 +32,8 @@ func SetLocale(locale string) {
 //
 // This function is used by the Go code generated by the goi18n command line tool.
 func AddTranslation(locale, context, content, translation string) string {
-	if translations[locale] == nil {
-		translations[locale] = make(map[string]string)
-	}
 	id := msg.Id(context, content)
-	translations[locale][id] = translation
+	defaultBundle.AddMessage(locale, id, translation)
 	return id
 }
 
@@ -51,9 +61,165 @@ type Message struct {
 // If there is no translation for the current locale, the message
 // is returned untranslated
 func (m *Message) String() string {
-	t, found := translations[currentLocale][m.id]
+	t, found := defaultBundle.Translation(currentLocale, m.id)
 	if !found {
-		t = translations[defaultLocale][m.id]
+		t, _ = defaultBundle.Translation(defaultLocale, m.id)
 	}
 	return t
 }
+
+// AddPluralTranslation adds a set of per-plural-category translated templates
+// to the dictionary and returns an id for the message.
+//
+// templates is keyed by plural.Category (e.g. plural.One, plural.Other).
+// Categories that locale's Language does not define are dropped; see
+// Bundle.AddPluralMessage.
+func AddPluralTranslation(locale, context string, templates map[plural.Category]string) string {
+	id := msg.Id(context, templates[plural.Other])
+	defaultBundle.AddPluralMessage(locale, id, templates)
+	return id
+}
+
+// NewPluralMessage returns a PluralMessage that may be translated into
+// multiple languages, selecting a different template per CLDR plural
+// category (e.g. "one" vs "other").
+//
+// templates must include an entry for plural.Other; it is used as the
+// canonical content for the message id and as the fallback template.
+func NewPluralMessage(context string, templates map[plural.Category]string) *PluralMessage {
+	id := AddPluralTranslation(defaultLocale, context, templates)
+	return &PluralMessage{id: id}
+}
+
+// PluralMessage is a string that is translated into multiple languages,
+// choosing between several templates based on the plural category of a
+// number.
+type PluralMessage struct {
+	id string
+}
+
+// Plural returns the message translated in the current locale, selecting
+// the template whose plural category matches number under the given
+// pluralType (language.Cardinal or language.Ordinal). pluralType must not
+// be language.Range, which needs a (start, end) pair rather than a
+// single number; use RangePlural for that.
+func (m *PluralMessage) Plural(pluralType language.PluralType, number interface{}) (string, error) {
+	return m.PluralWithData(pluralType, number, nil)
+}
+
+// PluralWithData is like Plural but also renders the selected template
+// with text/template, using data in addition to .Count, which is set to
+// number.
+func (m *PluralMessage) PluralWithData(pluralType language.PluralType, number interface{}, data map[string]interface{}) (string, error) {
+	lang := language.Parse(currentLocale)
+	if lang == nil {
+		return "", fmt.Errorf("i18n: unable to parse language from locale %q", currentLocale)
+	}
+
+	var category plural.Category
+	var err error
+	switch pluralType {
+	case language.Range:
+		return "", fmt.Errorf("i18n: language.Range requires a (start, end) pair; use RangePlural instead of Plural")
+	case language.Ordinal:
+		category, err = lang.OrdinalCategory(number)
+	default:
+		category, err = lang.PluralCategory(number)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	tmplStr, found := lookupPluralTemplate(currentLocale, m.id, category)
+	if !found {
+		return "", fmt.Errorf("i18n: no plural translation for message %q in locale %q", m.id, currentLocale)
+	}
+	return renderPluralTemplate(tmplStr, number, data)
+}
+
+// RangePlural returns the message translated in the current locale,
+// selecting the template whose plural category matches the range
+// [start, end] (e.g. French "de 1 à 2" vs "de 2 à 9").
+func (m *PluralMessage) RangePlural(start, end interface{}) (string, error) {
+	return m.RangePluralWithData(start, end, nil)
+}
+
+// RangePluralWithData is like RangePlural but also renders the selected
+// template with text/template, using data in addition to .Start and .End,
+// which are set to start and end.
+func (m *PluralMessage) RangePluralWithData(start, end interface{}, data map[string]interface{}) (string, error) {
+	lang := language.Parse(currentLocale)
+	if lang == nil {
+		return "", fmt.Errorf("i18n: unable to parse language from locale %q", currentLocale)
+	}
+	category, err := lang.RangeCategory(start, end)
+	if err != nil {
+		return "", err
+	}
+
+	tmplStr, found := lookupPluralTemplate(currentLocale, m.id, category)
+	if !found {
+		return "", fmt.Errorf("i18n: no plural translation for message %q in locale %q", m.id, currentLocale)
+	}
+	return renderRangeTemplate(tmplStr, start, end, data)
+}
+
+// lookupPluralTemplate walks the locale-resolution chain (e.g. pt-BR -> pt
+// -> defaultLocale), returning the first template registered for category,
+// falling back to plural.Other at each locale before moving on.
+func lookupPluralTemplate(locale, id string, category plural.Category) (string, bool) {
+	for {
+		if tmpl, ok := defaultBundle.PluralTemplate(locale, id, category); ok {
+			return tmpl, true
+		}
+		if locale == defaultLocale {
+			return "", false
+		}
+		if end := strings.LastIndex(locale, "-"); end >= 0 {
+			locale = locale[:end]
+		} else {
+			locale = defaultLocale
+		}
+	}
+}
+
+// renderPluralTemplate parses and executes tmplStr with text/template,
+// exposing number as .Count alongside the optional data.
+func renderPluralTemplate(tmplStr string, number interface{}, data map[string]interface{}) (string, error) {
+	tmpl, err := template.New("plural").Parse(tmplStr)
+	if err != nil {
+		return "", err
+	}
+	templateData := make(map[string]interface{}, len(data)+1)
+	for k, v := range data {
+		templateData[k] = v
+	}
+	templateData["Count"] = number
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderRangeTemplate parses and executes tmplStr with text/template,
+// exposing start and end as .Start and .End alongside the optional data.
+func renderRangeTemplate(tmplStr string, start, end interface{}, data map[string]interface{}) (string, error) {
+	tmpl, err := template.New("plural").Parse(tmplStr)
+	if err != nil {
+		return "", err
+	}
+	templateData := make(map[string]interface{}, len(data)+2)
+	for k, v := range data {
+		templateData[k] = v
+	}
+	templateData["Start"] = start
+	templateData["End"] = end
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, templateData); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,112 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/nicksnyder/go-i18n/i18n/language"
+	"github.com/nicksnyder/go-i18n/i18n/plural"
+)
+
+func TestMessageLocaleFallback(t *testing.T) {
+	defer SetLocale(defaultLocale)
+
+	AddTranslation(defaultLocale, "greeting", "Hello", "Hello")
+	id := AddTranslation("fr", "greeting", "Hello", "Bonjour")
+	m := &Message{id: id}
+
+	SetLocale("fr")
+	if got, want := m.String(), "Bonjour"; got != want {
+		t.Errorf("String() in fr = %q, want %q", got, want)
+	}
+
+	// Message.String only checks currentLocale and defaultLocale, unlike
+	// PluralMessage's lookupPluralTemplate, which walks the full
+	// region-stripping chain. A locale with no exact translation falls
+	// straight through to the default.
+	SetLocale("fr-CA")
+	if got, want := m.String(), "Hello"; got != want {
+		t.Errorf("String() in fr-CA = %q, want %q (no exact translation, falls to default)", got, want)
+	}
+
+	SetLocale("de")
+	if got, want := m.String(), "Hello"; got != want {
+		t.Errorf("String() in de = %q, want %q (falls to default)", got, want)
+	}
+}
+
+func TestPluralMessageCardinal(t *testing.T) {
+	defer SetLocale(defaultLocale)
+
+	m := NewPluralMessage("TestPluralMessageCardinal", map[plural.Category]string{
+		plural.One:   "{{.Count}} book",
+		plural.Other: "{{.Count}} books",
+	})
+
+	SetLocale("en")
+	if got, err := m.Plural(language.Cardinal, 1); err != nil {
+		t.Fatalf("Plural(Cardinal, 1): %v", err)
+	} else if want := "1 book"; got != want {
+		t.Errorf("Plural(Cardinal, 1) = %q, want %q", got, want)
+	}
+
+	if got, err := m.Plural(language.Cardinal, 2); err != nil {
+		t.Fatalf("Plural(Cardinal, 2): %v", err)
+	} else if want := "2 books"; got != want {
+		t.Errorf("Plural(Cardinal, 2) = %q, want %q", got, want)
+	}
+}
+
+func TestPluralMessageLocaleFallback(t *testing.T) {
+	defer SetLocale(defaultLocale)
+
+	m := NewPluralMessage("TestPluralMessageLocaleFallback", map[plural.Category]string{
+		plural.One:   "{{.Count}} livre",
+		plural.Other: "{{.Count}} livres",
+	})
+
+	// Only the default-locale templates were registered; lookupPluralTemplate
+	// must walk fr-CA -> fr -> defaultLocale to find them.
+	SetLocale("fr-CA")
+	got, err := m.Plural(language.Cardinal, 1)
+	if err != nil {
+		t.Fatalf("Plural(Cardinal, 1) in fr-CA: %v", err)
+	}
+	if want := "1 livre"; got != want {
+		t.Errorf("Plural(Cardinal, 1) in fr-CA = %q, want %q", got, want)
+	}
+}
+
+func TestPluralMessagePluralRejectsRange(t *testing.T) {
+	defer SetLocale(defaultLocale)
+
+	m := NewPluralMessage("TestPluralMessagePluralRejectsRange", map[plural.Category]string{
+		plural.Other: "{{.Count}} things",
+	})
+
+	SetLocale("en")
+	if _, err := m.Plural(language.Range, 1); err == nil {
+		t.Error("Plural(Range, 1) returned a nil error; want an error directing the caller to RangePlural")
+	}
+}
+
+func TestPluralMessageRangePlural(t *testing.T) {
+	defer SetLocale(defaultLocale)
+
+	m := NewPluralMessage("TestPluralMessageRangePlural", map[plural.Category]string{
+		plural.One:   "de {{.Start}} à {{.End}}",
+		plural.Other: "de {{.Start}} à {{.End}} (autres)",
+	})
+
+	SetLocale("fr")
+	if got, err := m.RangePlural(1, 2); err != nil {
+		t.Fatalf("RangePlural(1, 2): %v", err)
+	} else if want := "de 1 à 2"; got != want {
+		t.Errorf("RangePlural(1, 2) = %q, want %q", got, want)
+	}
+
+	if got, err := m.RangePlural(2, 9); err != nil {
+		t.Fatalf("RangePlural(2, 9): %v", err)
+	} else if want := "de 2 à 9 (autres)"; got != want {
+		t.Errorf("RangePlural(2, 9) = %q, want %q", got, want)
+	}
+}